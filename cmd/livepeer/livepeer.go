@@ -15,35 +15,31 @@ import (
 	"os"
 	"os/exec"
 	"path"
-	"path/filepath"
 	"runtime"
 	"time"
 
 	crypto "gx/ipfs/QmaPbCnUMBohSGo3KnxEa2bHqyJVVeEEcwtqJAYxerieBo/go-libp2p-crypto"
 
-	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
-	"github.com/ethereum/go-ethereum/common"
-	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/golang/glog"
-	bnet "github.com/livepeer/go-livepeer-basicnet"
+	"go.uber.org/fx"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/livepeer/go-livepeer/common/log"
+	"github.com/livepeer/go-livepeer/config"
 	"github.com/livepeer/go-livepeer/core"
 	"github.com/livepeer/go-livepeer/eth"
 	"github.com/livepeer/go-livepeer/mediaserver"
 	"github.com/livepeer/go-livepeer/net"
-	"github.com/livepeer/go-livepeer/types"
 )
 
+var logger = log.New("module", "main")
+
 var ErrKeygen = errors.New("ErrKeygen")
 var EthRpcTimeout = 10 * time.Second
 var EthEventTimeout = 30 * time.Second
 var EthMinedTxTimeout = 60 * time.Second
 
 func main() {
-	flag.Set("logtostderr", "true")
-
 	//Stream Command
 	streamCmd := flag.NewFlagSet("stream", flag.ExitOnError)
 	streamID := streamCmd.String("id", "", "Stream ID")
@@ -54,6 +50,17 @@ func main() {
 	brtmp := broadcastCmd.Int("rtmp", 1935, "RTMP port for broadcasting.")
 	bhttp := broadcastCmd.Int("http", 8935, "HTTP port for getting broadcast streamID.")
 
+	//Config Command
+	configCmd := flag.NewFlagSet("config", flag.ExitOnError)
+	configOut := configCmd.String("o", "", "Write the default config here instead of stdout")
+	validateCmd := flag.NewFlagSet("config validate", flag.ExitOnError)
+	validateFile := validateCmd.String("f", "", "Config file to validate")
+
+	//ENR Command
+	enrCmd := flag.NewFlagSet("enr", flag.ExitOnError)
+	enrPort := enrCmd.Int("p", 15000, "port this node's record advertises")
+	enrDatadir := enrCmd.String("datadir", "./data", "data directory")
+
 	if len(os.Args) > 1 {
 		if os.Args[1] == "stream" {
 			streamCmd.Parse(os.Args[2:])
@@ -63,15 +70,34 @@ func main() {
 			broadcastCmd.Parse(os.Args[2:])
 			broadcast(*brtmp, *bhttp)
 			return
+		} else if os.Args[1] == "config" {
+			if len(os.Args) > 2 && os.Args[2] == "validate" {
+				validateCmd.Parse(os.Args[3:])
+				configValidate(*validateFile)
+			} else {
+				configCmd.Parse(os.Args[2:])
+				configGenerate(*configOut)
+			}
+			return
+		} else if os.Args[1] == "enr" {
+			enrCmd.Parse(os.Args[2:])
+			printENR(*enrDatadir, *enrPort)
+			return
 		}
 	}
 
-	port := flag.Int("p", 15000, "port")
-	httpPort := flag.String("http", "8935", "http port")
-	rtmpPort := flag.String("rtmp", "1935", "rtmp port")
-	datadir := flag.String("datadir", "./data", "data directory")
-	bootID := flag.String("bootID", "12208a4eb428aa57a74ef0593612adb88077c75c71ad07c3c26e4e7a8d4860083b01", "Bootstrap node ID")
-	bootAddr := flag.String("bootAddr", "/ip4/52.15.174.204/tcp/15000", "Bootstrap node addr")
+	verbosity := flag.Int("verbosity", 3, "Log verbosity, 0 (silent) to 5 (debug)")
+	vmodule := flag.String("vmodule", "", "Per-module log verbosity, e.g. 'eth=5,net=2'")
+	logJSON := flag.Bool("logjson", false, "Emit logs as JSON instead of the human-readable format")
+
+	configFile := flag.String("config", "", "LivepeerConfig file (YAML or TOML)")
+	port := flag.Int("p", 0, "port")
+	httpPort := flag.String("http", "", "http port")
+	rtmpPort := flag.String("rtmp", "", "rtmp port")
+	datadir := flag.String("datadir", "", "data directory")
+	bootID := flag.String("bootID", "", "Bootstrap node ID")
+	bootAddr := flag.String("bootAddr", "", "Bootstrap node addr")
+	bootnodes := flag.String("bootnodes", "", "Comma-separated list of peerID@multiaddr bootstrap nodes")
 	bootnode := flag.Bool("bootnode", false, "Set to true if starting bootstrap node")
 	transcoder := flag.Bool("transcoder", false, "Set to true to be a transcoder")
 	newEthAccount := flag.Bool("newEthAccount", false, "Create an eth account")
@@ -81,128 +107,142 @@ func main() {
 
 	flag.Parse()
 
-	if *port == 0 {
-		glog.Fatalf("Please provide port")
-	}
-	if *httpPort == "" {
-		glog.Fatalf("Please provide http port")
-	}
-	if *rtmpPort == "" {
-		glog.Fatalf("Please provide rtmp port")
-	}
-
-	if _, err := os.Stat(*datadir); os.IsNotExist(err) {
-		os.Mkdir(*datadir, 0755)
+	if err := log.Setup(*verbosity, *vmodule, *logJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "Error setting up logging: %v\n", err)
+		return
 	}
 
-	priv, pub, err := getLPKeys(*datadir)
+	cfg, err := config.Load(*configFile)
 	if err != nil {
-		glog.Errorf("Error getting keys: %v", err)
+		logger.Error("error loading config", "err", err)
 		return
 	}
 
-	node, err := bnet.NewNode(*port, priv, pub)
-	if err != nil {
-		glog.Errorf("Error creating a new node: %v", err)
-		return
+	cfg = config.ApplyFlagOverrides(flag.CommandLine, cfg, config.FlagOverrides{
+		Port:          *port,
+		HTTPPort:      *httpPort,
+		RTMPPort:      *rtmpPort,
+		Datadir:       *datadir,
+		BootID:        *bootID,
+		BootAddr:      *bootAddr,
+		Bootnodes:     *bootnodes,
+		Bootnode:      *bootnode,
+		Transcoder:    *transcoder,
+		NewEthAccount: *newEthAccount,
+		EthPassword:   *ethPassword,
+		GethIPC:       *gethipc,
+		ProtocolAddr:  *protocolAddr,
+	})
+
+	if cfg.Network.Port == 0 {
+		logger.Fatal("please provide port")
+	}
+	if cfg.HTTPPort == "" {
+		logger.Fatal("please provide http port")
+	}
+	if cfg.RTMPPort == "" {
+		logger.Fatal("please provide rtmp port")
 	}
-	nw, err := bnet.NewBasicVideoNetwork(node)
+
+	runNode(cfg)
+}
+
+// runNode loads the keys out of cfg.Datadir, assembles the Fx graph
+// (network, eth client, core node, media server) the way cfg asks for it,
+// and runs it.  Every subsystem is a provider in its own package's Module;
+// runNode just decides, based on cfg, which modules to include.
+func runNode(cfg config.LivepeerConfig) {
+	if _, err := os.Stat(cfg.Datadir); os.IsNotExist(err) {
+		os.Mkdir(cfg.Datadir, 0755)
+	}
+
+	priv, pub, err := getLPKeys(cfg.Datadir)
 	if err != nil {
-		glog.Errorf("Cannot create network node: %v", err)
+		logger.Error("error getting keys", "err", err)
 		return
 	}
 
-	n, err := core.NewLivepeerNode(nil, nw)
-	if err != nil {
-		glog.Errorf("Error creating livepeer node: %v", err)
+	modules := []fx.Option{
+		net.Module,
+		core.Module,
+		mediaserver.Module,
+		fx.Provide(func() net.NetworkConfig {
+			return net.NetworkConfig{Port: cfg.Network.Port, PrivKey: priv, PubKey: pub}
+		}),
+		fx.Provide(func() mediaserver.ServerConfig {
+			return mediaserver.ServerConfig{RTMPPort: cfg.RTMPPort, HTTPPort: cfg.HTTPPort}
+		}),
+		fx.Invoke(registerBootstrap(cfg)),
 	}
 
-	if *bootnode {
-		glog.Infof("\n\nSetting up bootnode")
-		//Setup boostrap node
-		if err := n.VideoNetwork.SetupProtocol(); err != nil {
-			glog.Errorf("Cannot set up protocol:%v", err)
-			return
+	if cfg.Eth.GethIPC != "" {
+		modules = append(modules,
+			eth.Module,
+			fx.Provide(func() eth.ClientConfig {
+				return eth.ClientConfig{
+					GethIPC:       cfg.Eth.GethIPC,
+					ProtocolAddr:  cfg.Eth.ProtocolAddr,
+					Datadir:       cfg.Datadir,
+					Password:      cfg.Eth.Password,
+					NewEthAccount: cfg.Eth.NewEthAccount,
+					RPCTimeout:    EthRpcTimeout,
+					EventTimeout:  EthEventTimeout,
+				}
+			}),
+			fx.Invoke(func(n *core.LivepeerNode, client eth.LivepeerEthClient) {
+				core.AttachEthClient(n, client, cfg.Eth.Password)
+			}),
+		)
+
+		if cfg.Transcoder.Enabled {
+			modules = append(modules, fx.Invoke(registerTranscoder(cfg)))
 		}
 	} else {
-		if err := n.Start(*bootID, *bootAddr); err != nil {
-			glog.Errorf("Cannot connect to bootstrap node: %v", err)
-			return
-		}
+		logger.Info("livepeer is in off-chain mode")
 	}
 
-	//Set up ethereum-related stuff
-	if *gethipc != "" {
-		var backend *ethclient.Client
-		var acct accounts.Account
-
-		if *newEthAccount {
-			keyStore := keystore.NewKeyStore(filepath.Join(*datadir, "keystore"), keystore.StandardScryptN, keystore.StandardScryptP)
-			acct, err = keyStore.NewAccount(*ethPassword)
-			if err != nil {
-				glog.Errorf("Error creating new eth account: %v", err)
-				return
-			}
-		} else {
-			acct, err = getEthAccount(*datadir)
-			if err != nil {
-				glog.Errorf("Error getting Eth account: %v", err)
-				return
-			}
-		}
-		glog.Infof("Connecting to geth @ %v", *gethipc)
-		backend, err = ethclient.Dial(*gethipc)
-		if err != nil {
-			glog.Errorf("Failed to connect to Ethereum client: %v", err)
-			return
-		}
+	app := fx.New(modules...)
+	app.Run()
+}
 
-		client, err := eth.NewClient(acct, *ethPassword, *datadir, backend, common.HexToAddress(*protocolAddr), EthRpcTimeout, EthEventTimeout)
-		if err != nil {
-			glog.Errorf("Error creating Eth client: %v", err)
-			return
-		}
-		n.Eth = client
-		n.EthPassword = *ethPassword
+// configGenerate writes a fully-populated default LivepeerConfig to out, or
+// to stdout when out is empty.
+func configGenerate(out string) {
+	b, err := yaml.Marshal(config.Default())
+	if err != nil {
+		logger.Error("error marshaling default config", "err", err)
+		return
+	}
 
-		if *transcoder {
-			logsSub, err := setupTranscoder(n, acct)
+	if out == "" {
+		fmt.Print(string(b))
+		return
+	}
+	if err := ioutil.WriteFile(out, b, 0644); err != nil {
+		logger.Error("error writing config", "path", out, "err", err)
+	}
+}
 
-			if err != nil {
-				glog.Errorf("Error subscribing to job event: %v", err)
-			}
-			defer logsSub.Unsubscribe()
-			// defer close(logsChan)
-		}
-	} else {
-		glog.Infof("***Livepeer is in off-chain mode***")
+// configValidate parses the config file at path and reports unknown keys
+// and missing required fields.
+func configValidate(path string) {
+	if path == "" {
+		logger.Error("need to specify a config file via -f")
+		return
 	}
 
-	//Set up the media server
-	glog.Infof("\n\nSetting up Media Server")
-	s := mediaserver.NewLivepeerMediaServer(*rtmpPort, *httpPort, "", n)
-	ec := make(chan error)
-	msCtx, cancel := context.WithCancel(context.Background())
-	go func() {
-		ec <- s.StartMediaServer(msCtx)
-	}()
-
-	select {
-	case err := <-ec:
-		glog.Infof("Error from media server: %v", err)
-		cancel()
+	problems, err := config.Validate(path)
+	if err != nil {
+		logger.Error("error reading config", "path", path, "err", err)
 		return
-	case <-msCtx.Done():
-		glog.Infof("MediaServer Done()")
-		cancel()
+	}
+	if len(problems) == 0 {
+		fmt.Printf("%v is valid\n", path)
 		return
 	}
-	// if err := s.StartMediaServer(context.Background()); err != nil {
-	// 	glog.Errorf("Failed to start LPMS: %v", err)
-	// 	return
-	// }
-
-	// select {}
+	for _, p := range problems {
+		fmt.Println(p)
+	}
 }
 
 type LPKeyFile struct {
@@ -262,10 +302,10 @@ func getLPKeys(datadir string) (crypto.PrivKey, crypto.PubKey, error) {
 	}
 
 	if gen == true || pub == nil || priv == nil {
-		glog.Errorf("Cannot file keys in data dir %v, creating new keys", datadir)
+		logger.Error("cannot find keys in data dir, creating new keys", "datadir", datadir)
 		priv, pub, err := crypto.GenerateKeyPair(crypto.RSA, 2048)
 		if err != nil {
-			glog.Errorf("Error generating keypair: %v", err)
+			logger.Error("error generating keypair", "err", err)
 			return nil, nil, ErrKeygen
 		}
 
@@ -277,10 +317,10 @@ func getLPKeys(datadir string) (crypto.PrivKey, crypto.PubKey, error) {
 			kf := LPKeyFile{Priv: crypto.ConfigEncodeKey(privb), Pub: crypto.ConfigEncodeKey(pubb)}
 			kfb, err := json.Marshal(kf)
 			if err != nil {
-				glog.Errorf("Error writing keyfile to datadir: %v", err)
+				logger.Error("error writing keyfile to datadir", "err", err)
 			} else {
 				if err := ioutil.WriteFile(path.Join(datadir, "keys.json"), kfb, 0644); err != nil {
-					glog.Errorf("Error writing keyfile to datadir: %v", err)
+					logger.Error("error writing keyfile to datadir", "err", err)
 				}
 			}
 		}
@@ -291,97 +331,177 @@ func getLPKeys(datadir string) (crypto.PrivKey, crypto.PubKey, error) {
 	return priv, pub, nil
 }
 
-func getEthAccount(datadir string) (accounts.Account, error) {
-	keyStore := keystore.NewKeyStore(filepath.Join(datadir, "keystore"), keystore.StandardScryptN, keystore.StandardScryptP)
-	accts := keyStore.Accounts()
-	if len(accts) == 0 {
-		glog.Errorf("Cannot find geth account.  Make sure the data directory contains keys, or use -newEthAccount to create a new account.")
-		return accounts.Account{}, fmt.Errorf("ErrGeth")
-	}
+// registerBootstrap returns an fx.Invoke-able func that wires n's
+// construct-then-Run(ctx) lifecycle into Fx: OnStart assembles the
+// bootstrap candidate set (the -bootnodes flag, datadir/bootnodes.json, and
+// peers warm-started from a prior run's datadir/peers.json), brings up
+// bootstrap/protocol setup and the network's background loop, and starts a
+// goroutine that periodically snapshots the peerstore back to
+// datadir/peers.json. OnStop cancels that goroutine and tears the network
+// down via n.Stop().
+//
+// n.Run and persistKnownPeers both start loops meant to run for the app's
+// lifetime, so they're handed a context derived from context.Background()
+// rather than OnStart's ctx - the latter is only valid for the duration of
+// the start phase and would cancel the loops right after startup.
+func registerBootstrap(cfg config.LivepeerConfig) interface{} {
+	return func(lc fx.Lifecycle, n *core.LivepeerNode) {
+		var cancel context.CancelFunc
+
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				if cfg.Network.Bootnode {
+					logger.Info("setting up bootnode")
+				}
 
-	return accts[0], nil
-}
+				entries := append([]string{}, cfg.Network.Bootnodes...)
+				if cfg.Network.BootID != "" && cfg.Network.BootAddr != "" {
+					entries = append(entries, cfg.Network.BootID+"@"+cfg.Network.BootAddr)
+				}
 
-func setupTranscoder(n *core.LivepeerNode, acct accounts.Account) (ethereum.Subscription, error) {
-	//Check if transcoder is active
-	active, err := n.Eth.IsActiveTranscoder()
-	if err != nil {
-		glog.Errorf("Error getting transcoder state: %v", err)
-	}
+				bootnodes, err := net.LoadBootnodes(cfg.Datadir, entries)
+				if err != nil {
+					logger.Error("error loading bootnodes", "err", err)
+					return err
+				}
+				known, err := net.LoadKnownPeers(cfg.Datadir)
+				if err != nil {
+					logger.Error("error loading known peers", "err", err)
+					return err
+				}
+				bootnodes = append(bootnodes, known...)
 
-	if !active {
-		glog.Infof("Transcoder %v is inactive", acct.Address.Hex())
-	} else {
-		s, err := n.Eth.TranscoderStake()
-		if err != nil {
-			glog.Errorf("Error getting transcoder stake: %v", err)
-		}
-		glog.Infof("Transcoder Active. Total Stake: %v", s)
-	}
+				var runCtx context.Context
+				runCtx, cancel = context.WithCancel(context.Background())
 
-	rm := core.NewRewardManager(time.Second*5, n.Eth)
-	go rm.Start(context.Background())
+				if err := n.Run(runCtx, cfg.Network.Bootnode, bootnodes); err != nil {
+					cancel()
+					return err
+				}
 
-	//Subscribe to when a job is assigned to us
-	logsCh := make(chan ethtypes.Log)
-	sub, err := n.Eth.SubscribeToJobEvent(context.Background(), logsCh)
-	if err != nil {
-		glog.Errorf("Error subscribing to job event: %v", err)
+				go persistKnownPeers(runCtx, n, cfg.Datadir)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				if cancel != nil {
+					cancel()
+				}
+				return n.Stop()
+			},
+		})
 	}
-	go func() error {
-		select {
-		case l := <-logsCh:
-			tx, _, err := n.Eth.Backend().TransactionByHash(context.Background(), l.TxHash)
-			if err != nil {
-				glog.Errorf("Error getting transaction data: %v", err)
-			}
-			strmId, tData, err := eth.ParseJobTxData(tx.Data())
-			if err != nil {
-				glog.Errorf("Error parsing job tx data: %v", err)
-			}
+}
 
-			jid, _, _, _, err := eth.GetInfoFromJobEvent(l, n.Eth)
-			if err != nil {
-				glog.Errorf("Error getting info from job event: %v", err)
-			}
+// persistKnownPeers periodically snapshots the network's peerstore to
+// datadir/peers.json, so a restart can warm-start without the seed node.
+func persistKnownPeers(ctx context.Context, n *core.LivepeerNode, datadir string) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
 
-			//Create Transcode Config
-			//TODO: profile should contain multiple video profiles.  Waiting for a protocol change.
-			profile, ok := types.VideoProfileLookup[tData]
-			if !ok {
-				glog.Errorf("Cannot find video profile for job: %v", tData)
-				return core.ErrTranscode
+	for {
+		select {
+		case <-ticker.C:
+			if err := net.PersistPeers(datadir, n.VideoNetwork.KnownPeers()); err != nil {
+				logger.Error("error persisting known peers", "err", err)
 			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-			tProfiles := []types.VideoProfile{profile}
-			config := net.TranscodeConfig{StrmID: strmId, Profiles: tProfiles, JobID: jid, PerformOnchainClaim: true}
-			glog.Infof("Transcoder got job %v - strmID: %v, tData: %v, config: %v", tx.Hash(), strmId, tData, config)
+// printENR prints the local node's self-signed PeerRecord - the record an
+// operator shares with others so they can add this node to their
+// -bootnodes list.
+func printENR(datadir string, port int) {
+	priv, pub, err := getLPKeys(datadir)
+	if err != nil {
+		logger.Error("error getting keys", "err", err)
+		return
+	}
 
-			//Do The Transcoding
-			cm := core.NewClaimManager(strmId, jid, tProfiles, n.Eth)
-			strmIDs, err := n.Transcode(config, cm)
-			if err != nil {
-				glog.Errorf("Transcode Error: %v", err)
-			}
+	// Port 0 here, not port: this network exists only so GetNodeID can read
+	// back the peer ID bnet derives for priv/pub, not to actually listen -
+	// the real node may already be running and bound to port, and binding
+	// it again here would fail with "address in use".
+	nw, err := net.NewBasicVideoNetwork(net.NetworkConfig{Port: 0, PrivKey: priv, PubKey: pub})
+	if err != nil {
+		logger.Error("error constructing network", "err", err)
+		return
+	}
+	defer nw.Stop()
 
-			//Notify Broadcaster
-			sid := core.StreamID(strmId)
-			err = n.NotifyBroadcaster(sid.GetNodeID(), sid, map[core.StreamID]types.VideoProfile{strmIDs[0]: types.VideoProfileLookup[tData]})
-			if err != nil {
-				glog.Errorf("Notify Broadcaster Error: %v", err)
-			}
+	addr := fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", port)
+	record, err := net.NewPeerRecord(priv, pub, nw.GetNodeID(), []string{addr}, uint64(time.Now().Unix()))
+	if err != nil {
+		logger.Error("error building peer record", "err", err)
+		return
+	}
 
-			return nil
+	b, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		logger.Error("error marshaling peer record", "err", err)
+		return
+	}
+	fmt.Println(string(b))
+}
 
-		}
-	}()
+// registerTranscoder returns an fx.Invoke-able func that wires the
+// transcoder's reward manager and job subscription into the Fx lifecycle,
+// closing over cfg the same way registerBootstrap does so that
+// cfg.Transcoder.RewardManagerInterval (an operator's YAML/env/flag
+// override, not just its Default()) actually reaches NewRewardManager.  The
+// job subscription itself follows the same construct-then-Run(ctx)/Stop()
+// pattern as the network: OnStart starts the JobSubscriber's persistent
+// event loop, OnStop tears it down - replacing the old
+// `defer logsSub.Unsubscribe()` + one-shot select.
+func registerTranscoder(cfg config.LivepeerConfig) interface{} {
+	return func(lc fx.Lifecycle, n *core.LivepeerNode, acct accounts.Account) {
+		js := core.NewJobSubscriber(n)
+		var rmCancel context.CancelFunc
+
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				active, err := n.Eth.IsActiveTranscoder()
+				if err != nil {
+					logger.Error("error getting transcoder state", "err", err)
+				}
+				if !active {
+					logger.Info("transcoder is inactive", "address", acct.Address.Hex())
+				} else {
+					s, err := n.Eth.TranscoderStake()
+					if err != nil {
+						logger.Error("error getting transcoder stake", "err", err)
+					}
+					logger.Info("transcoder active", "totalStake", s)
+				}
 
-	return sub, nil
+				// rm's context is derived from Background, not ctx, for the
+				// same reason JobSubscriber.Run derives its loop context
+				// that way: ctx is only valid for the duration of this
+				// OnStart hook. rmCancel is what OnStop uses to actually
+				// stop it.
+				rmCtx, cancel := context.WithCancel(context.Background())
+				rmCancel = cancel
+				rm := core.NewRewardManager(cfg.Transcoder.RewardManagerInterval, n.Eth)
+				go rm.Start(rmCtx)
+
+				return js.Run(ctx)
+			},
+			OnStop: func(ctx context.Context) error {
+				if rmCancel != nil {
+					rmCancel()
+				}
+				js.Stop()
+				return nil
+			},
+		})
+	}
 }
 
 func stream(port string, streamID string) {
 	if streamID == "" {
-		glog.Errorf("Need to specify streamID via -id")
+		logger.Error("need to specify streamID via -id")
 		return
 	}
 
@@ -390,22 +510,22 @@ func stream(port string, streamID string) {
 		url := fmt.Sprintf("http://localhost:%v/stream/%v.m3u8", port, streamID)
 
 		cmd := exec.Command("ffplay", url)
-		glog.Infof("url: %v", url)
+		logger.Info("streaming", "url", url)
 		err := cmd.Start()
 		if err != nil {
-			glog.Infof("Couldn't start the stream")
+			logger.Error("couldn't start the stream")
 			os.Exit(1)
 		}
-		glog.Infof("Now streaming")
+		logger.Info("now streaming")
 		err = cmd.Wait()
 		if time.Since(start) > time.Second*10 { //cmd.Wait() doesn't return an error if ffplay failed.  What we are trying to prevent here is quitting too early from network latency.
 			if i < 2 {
-				glog.Infof("Error streaming video: %v, trying again\n\n", err)
+				logger.Error("error streaming video, trying again", "err", err)
 			} else {
-				glog.Infof("Error streaming video: %v", err)
+				logger.Error("error streaming video", "err", err)
 			}
 		} else {
-			glog.Infof("Finished the stream")
+			logger.Info("finished the stream")
 			return
 		}
 	}
@@ -422,30 +542,30 @@ func broadcast(rtmpPort int, httpPort int) {
 		cmd.Stderr = &stderr
 		err := cmd.Start()
 		if err != nil {
-			glog.Infof("Couldn't broadcast the stream: %v %v", err, stderr.String())
+			logger.Error("couldn't broadcast the stream", "err", err, "stderr", stderr.String())
 			os.Exit(1)
 		}
 
-		glog.Infof("Now broadcasting - %v%v", out.String(), stderr.String())
+		logger.Info("now broadcasting", "stdout", out.String(), "stderr", stderr.String())
 
 		time.Sleep(3 * time.Second)
 		resp, err := http.Get(fmt.Sprintf("http://localhost:%v/streamID", httpPort))
 		if err != nil {
-			glog.Errorf("Error getting stream ID: %v", err)
+			logger.Error("error getting stream ID", "err", err)
 		} else {
 			defer resp.Body.Close()
 			id, err := ioutil.ReadAll(resp.Body)
 			if err != nil {
-				glog.Errorf("Error reading stream ID: %v", err)
+				logger.Error("error reading stream ID", "err", err)
 			}
-			glog.Infof("StreamID: %v", string(id))
+			logger.Info("got streamID", "streamID", string(id))
 		}
 
 		if err = cmd.Wait(); err != nil {
-			glog.Errorf("Error running broadcast: %v\n%v", err, stderr.String())
+			logger.Error("error running broadcast", "err", err, "stderr", stderr.String())
 			return
 		}
 	} else {
-		glog.Errorf("The broadcast command only support darwin for now.  Please download OBS to broadcast.")
+		logger.Error("the broadcast command only supports darwin for now, please download OBS to broadcast")
 	}
 }
\ No newline at end of file
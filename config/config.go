@@ -0,0 +1,64 @@
+// Package config defines the typed, file-loadable configuration for a
+// Livepeer node.  It replaces the old ~15 individual cmd/livepeer flags with
+// a single LivepeerConfig, resolved with defaults -> config file -> LP_* env
+// vars -> flags precedence (see Load and ApplyFlagOverrides).
+package config
+
+import (
+	"time"
+
+	"github.com/livepeer/go-livepeer/types"
+)
+
+// NetworkConfig configures the p2p video network.
+type NetworkConfig struct {
+	Port      int      `mapstructure:"port"`
+	Bootnode  bool     `mapstructure:"bootnode"`
+	BootID    string   `mapstructure:"bootId"`
+	BootAddr  string   `mapstructure:"bootAddr"`
+	Bootnodes []string `mapstructure:"bootnodes"`
+}
+
+// EthConfig configures the connection to geth and the protocol contract.
+type EthConfig struct {
+	GethIPC       string `mapstructure:"gethipc"`
+	ProtocolAddr  string `mapstructure:"protocolAddr"`
+	Password      string `mapstructure:"password"`
+	NewEthAccount bool   `mapstructure:"newEthAccount"`
+}
+
+// TranscoderConfig configures a node's transcoder role.
+type TranscoderConfig struct {
+	Enabled               bool                 `mapstructure:"enabled"`
+	RewardManagerInterval time.Duration        `mapstructure:"rewardManagerInterval"`
+	Profiles              []types.VideoProfile `mapstructure:"profiles"`
+}
+
+// LivepeerConfig is the fully-resolved configuration for a node.  cmd/livepeer
+// loads one of these (see Load) and hands it straight to the Fx graph.
+type LivepeerConfig struct {
+	Datadir    string           `mapstructure:"datadir"`
+	RTMPPort   string           `mapstructure:"rtmpPort"`
+	HTTPPort   string           `mapstructure:"httpPort"`
+	Network    NetworkConfig    `mapstructure:"network"`
+	Eth        EthConfig        `mapstructure:"eth"`
+	Transcoder TranscoderConfig `mapstructure:"transcoder"`
+}
+
+// Default returns the configuration cmd/livepeer used to hardcode as flag
+// defaults.
+func Default() LivepeerConfig {
+	return LivepeerConfig{
+		Datadir:  "./data",
+		RTMPPort: "1935",
+		HTTPPort: "8935",
+		Network: NetworkConfig{
+			Port:     15000,
+			BootID:   "12208a4eb428aa57a74ef0593612adb88077c75c71ad07c3c26e4e7a8d4860083b01",
+			BootAddr: "/ip4/52.15.174.204/tcp/15000",
+		},
+		Transcoder: TranscoderConfig{
+			RewardManagerInterval: time.Second * 5,
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Load resolves a LivepeerConfig from defaults, overlaid with path (if
+// non-empty, a YAML or TOML file) and LP_*-prefixed environment variables.
+// Flag overrides, since they come from a stdlib flag.FlagSet rather than
+// viper, are applied afterwards with ApplyFlagOverrides.
+func Load(path string) (LivepeerConfig, error) {
+	v := viper.New()
+	v.SetEnvPrefix("LP")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	// v.AutomaticEnv only overrides keys viper already knows about, so every
+	// key needs a registered default before ReadInConfig/Unmarshal - otherwise
+	// an LP_* var for a key absent from the config file (or with no config
+	// file at all) is silently ignored.
+	registerDefaults(v, Default())
+
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return LivepeerConfig{}, err
+		}
+	}
+
+	cfg := Default()
+	if err := v.Unmarshal(&cfg); err != nil {
+		return LivepeerConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+// registerDefaults seeds v with every LivepeerConfig key at its default
+// value, using the same dotted paths the YAML/TOML mapstructure tags and
+// LP_* env var names resolve to (e.g. network.bootId -> LP_NETWORK_BOOTID).
+func registerDefaults(v *viper.Viper, d LivepeerConfig) {
+	v.SetDefault("datadir", d.Datadir)
+	v.SetDefault("rtmpPort", d.RTMPPort)
+	v.SetDefault("httpPort", d.HTTPPort)
+
+	v.SetDefault("network.port", d.Network.Port)
+	v.SetDefault("network.bootnode", d.Network.Bootnode)
+	v.SetDefault("network.bootId", d.Network.BootID)
+	v.SetDefault("network.bootAddr", d.Network.BootAddr)
+	v.SetDefault("network.bootnodes", d.Network.Bootnodes)
+
+	v.SetDefault("eth.gethipc", d.Eth.GethIPC)
+	v.SetDefault("eth.protocolAddr", d.Eth.ProtocolAddr)
+	v.SetDefault("eth.password", d.Eth.Password)
+	v.SetDefault("eth.newEthAccount", d.Eth.NewEthAccount)
+
+	v.SetDefault("transcoder.enabled", d.Transcoder.Enabled)
+	v.SetDefault("transcoder.rewardManagerInterval", d.Transcoder.RewardManagerInterval)
+	v.SetDefault("transcoder.profiles", d.Transcoder.Profiles)
+}
+
+// FlagOverrides holds the parsed values of cmd/livepeer's stdlib flags that
+// mirror LivepeerConfig fields. ApplyFlagOverrides uses fs.Visit to tell
+// which of these the user actually passed, as opposed to which are just
+// sitting at their flag.*Var default.
+type FlagOverrides struct {
+	Port          int
+	HTTPPort      string
+	RTMPPort      string
+	Datadir       string
+	BootID        string
+	BootAddr      string
+	Bootnodes     string
+	Bootnode      bool
+	Transcoder    bool
+	NewEthAccount bool
+	EthPassword   string
+	GethIPC       string
+	ProtocolAddr  string
+}
+
+// ApplyFlagOverrides overlays whichever of o's fields the user actually
+// passed a flag for (per fs.Visit) onto cfg - flags are the last and
+// narrowest layer of the defaults -> file -> LP_* env -> flags precedence
+// chain.
+func ApplyFlagOverrides(fs *flag.FlagSet, cfg LivepeerConfig, o FlagOverrides) LivepeerConfig {
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "p":
+			cfg.Network.Port = o.Port
+		case "http":
+			cfg.HTTPPort = o.HTTPPort
+		case "rtmp":
+			cfg.RTMPPort = o.RTMPPort
+		case "datadir":
+			cfg.Datadir = o.Datadir
+		case "bootID":
+			cfg.Network.BootID = o.BootID
+		case "bootAddr":
+			cfg.Network.BootAddr = o.BootAddr
+		case "bootnodes":
+			cfg.Network.Bootnodes = strings.Split(o.Bootnodes, ",")
+		case "bootnode":
+			cfg.Network.Bootnode = o.Bootnode
+		case "transcoder":
+			cfg.Transcoder.Enabled = o.Transcoder
+		case "newEthAccount":
+			cfg.Eth.NewEthAccount = o.NewEthAccount
+		case "ethPassword":
+			cfg.Eth.Password = o.EthPassword
+		case "gethipc":
+			cfg.Eth.GethIPC = o.GethIPC
+		case "protocolAddr":
+			cfg.Eth.ProtocolAddr = o.ProtocolAddr
+		}
+	})
+
+	return cfg
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := Default()
+	if cfg.Datadir != want.Datadir || cfg.RTMPPort != want.RTMPPort || cfg.HTTPPort != want.HTTPPort {
+		t.Fatalf("Load(\"\") = %+v, want Default() %+v", cfg, want)
+	}
+	if cfg.Network.Port != want.Network.Port || cfg.Network.BootID != want.Network.BootID {
+		t.Fatalf("Load(\"\").Network = %+v, want Default().Network %+v", cfg.Network, want.Network)
+	}
+}
+
+func TestLoadConfigFileOverridesDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	yaml := "rtmpPort: \"1936\"\nnetwork:\n  port: 16000\n"
+	if err := ioutil.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.RTMPPort != "1936" {
+		t.Fatalf("cfg.RTMPPort = %q, want %q", cfg.RTMPPort, "1936")
+	}
+	if cfg.Network.Port != 16000 {
+		t.Fatalf("cfg.Network.Port = %d, want 16000", cfg.Network.Port)
+	}
+	// Fields the file didn't touch should still fall back to Default().
+	if cfg.HTTPPort != Default().HTTPPort {
+		t.Fatalf("cfg.HTTPPort = %q, want untouched default %q", cfg.HTTPPort, Default().HTTPPort)
+	}
+}
+
+func TestLoadEnvOverridesDefaultsWithoutConfigFile(t *testing.T) {
+	os.Setenv("LP_RTMPPORT", "1937")
+	os.Setenv("LP_NETWORK_BOOTID", "env-boot-id")
+	defer os.Unsetenv("LP_RTMPPORT")
+	defer os.Unsetenv("LP_NETWORK_BOOTID")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.RTMPPort != "1937" {
+		t.Fatalf("cfg.RTMPPort = %q, want %q (from LP_RTMPPORT)", cfg.RTMPPort, "1937")
+	}
+	if cfg.Network.BootID != "env-boot-id" {
+		t.Fatalf("cfg.Network.BootID = %q, want %q (from LP_NETWORK_BOOTID)", cfg.Network.BootID, "env-boot-id")
+	}
+}
+
+func TestApplyFlagOverridesOnlyAppliesPassedFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	port := fs.Int("p", 0, "")
+	httpPort := fs.String("http", "", "")
+	if err := fs.Parse([]string{"-p", "9000"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	cfg := Default()
+	cfg = ApplyFlagOverrides(fs, cfg, FlagOverrides{Port: *port, HTTPPort: *httpPort})
+
+	if cfg.Network.Port != 9000 {
+		t.Fatalf("cfg.Network.Port = %d, want 9000 (flag was passed)", cfg.Network.Port)
+	}
+	if cfg.HTTPPort != Default().HTTPPort {
+		t.Fatalf("cfg.HTTPPort = %q, want untouched default %q (-http was never passed)", cfg.HTTPPort, Default().HTTPPort)
+	}
+}
+
+func TestApplyFlagOverridesBootnodesSplitsOnComma(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	bootnodes := fs.String("bootnodes", "", "")
+	if err := fs.Parse([]string{"-bootnodes", "a@addr1,b@addr2"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	cfg := ApplyFlagOverrides(fs, Default(), FlagOverrides{Bootnodes: *bootnodes})
+
+	want := []string{"a@addr1", "b@addr2"}
+	if len(cfg.Network.Bootnodes) != len(want) {
+		t.Fatalf("cfg.Network.Bootnodes = %v, want %v", cfg.Network.Bootnodes, want)
+	}
+	for i := range want {
+		if cfg.Network.Bootnodes[i] != want[i] {
+			t.Fatalf("cfg.Network.Bootnodes = %v, want %v", cfg.Network.Bootnodes, want)
+		}
+	}
+}
+
+func TestRegisterDefaultsRewardManagerInterval(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Transcoder.RewardManagerInterval != 5*time.Second {
+		t.Fatalf("cfg.Transcoder.RewardManagerInterval = %v, want %v", cfg.Transcoder.RewardManagerInterval, 5*time.Second)
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Validate parses the config file at path and reports problems: unknown
+// keys and missing fields that are required given the rest of the config.
+// It returns a nil slice when the file is clean.
+func Validate(path string) ([]string, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var problems []string
+
+	cfg := Default()
+	if err := v.UnmarshalExact(&cfg); err != nil {
+		problems = append(problems, fmt.Sprintf("unknown key(s) in config: %v", err))
+	}
+
+	if cfg.Eth.GethIPC != "" && cfg.Eth.ProtocolAddr == "" {
+		problems = append(problems, "eth.protocolAddr is required when eth.gethipc is set")
+	}
+	if cfg.Network.Port == 0 {
+		problems = append(problems, "network.port must be set")
+	}
+	if cfg.RTMPPort == "" {
+		problems = append(problems, "rtmpPort must be set")
+	}
+	if cfg.HTTPPort == "" {
+		problems = append(problems, "httpPort must be set")
+	}
+
+	return problems, nil
+}
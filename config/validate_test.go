@@ -0,0 +1,79 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, dir, yaml string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestValidateCleanConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := writeConfigFile(t, dir, "rtmpPort: \"1935\"\nhttpPort: \"8935\"\nnetwork:\n  port: 15000\n")
+
+	problems, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("Validate() = %v, want no problems", problems)
+	}
+}
+
+func TestValidateRejectsUnknownKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := writeConfigFile(t, dir, "rtmpPort: \"1935\"\nbogusKey: true\n")
+
+	problems, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if len(problems) == 0 {
+		t.Fatal("Validate() = no problems, want a complaint about the unknown key")
+	}
+}
+
+func TestValidateRequiresProtocolAddrWithGethIPC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := writeConfigFile(t, dir, "eth:\n  gethipc: /tmp/geth.ipc\n")
+
+	problems, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	found := false
+	for _, p := range problems {
+		if p == "eth.protocolAddr is required when eth.gethipc is set" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Validate() = %v, want a complaint about missing eth.protocolAddr", problems)
+	}
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/livepeer/go-livepeer/eth"
+	"github.com/livepeer/go-livepeer/net"
+	"github.com/livepeer/go-livepeer/types"
+)
+
+// JobSubscriber watches the protocol's job event log for jobs assigned to
+// this node and drives them through the node's transcode path.  Construction
+// does no I/O; Run subscribes and starts the event loop, Stop unsubscribes
+// and tears the loop down - mirroring how a watch service starts its own
+// loop once a context is available.
+type JobSubscriber struct {
+	node *LivepeerNode
+
+	cancel context.CancelFunc
+	sub    ethereum.Subscription
+}
+
+// NewJobSubscriber constructs a JobSubscriber for n.  It performs no I/O.
+func NewJobSubscriber(n *LivepeerNode) *JobSubscriber {
+	return &JobSubscriber{node: n}
+}
+
+// Run subscribes to the job event log and starts the event loop in the
+// background.  It returns once the subscription is established; it does not
+// block for the lifetime of the loop.  The loop's own context is derived
+// from context.Background() rather than ctx, since ctx is only valid for the
+// duration of the caller's OnStart hook and would tear the loop down right
+// after startup - Stop is what cancels it.
+func (js *JobSubscriber) Run(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+
+	logsCh := make(chan ethtypes.Log)
+	sub, err := js.node.Eth.SubscribeToJobEvent(runCtx, logsCh)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	js.cancel = cancel
+	js.sub = sub
+
+	go js.loop(runCtx, logsCh)
+	return nil
+}
+
+// Stop unsubscribes from the job event log and stops the event loop.  Safe
+// to call even if Run was never called or returned an error.
+func (js *JobSubscriber) Stop() {
+	if js.sub != nil {
+		js.sub.Unsubscribe()
+	}
+	if js.cancel != nil {
+		js.cancel()
+	}
+}
+
+func (js *JobSubscriber) loop(ctx context.Context, logsCh chan ethtypes.Log) {
+	for {
+		select {
+		case l := <-logsCh:
+			js.handleJobEvent(l)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (js *JobSubscriber) handleJobEvent(l ethtypes.Log) {
+	n := js.node
+
+	tx, _, err := n.Eth.Backend().TransactionByHash(context.Background(), l.TxHash)
+	if err != nil {
+		logger.Error("error getting transaction data", "txHash", l.TxHash, "err", err)
+		return
+	}
+	strmId, tData, err := eth.ParseJobTxData(tx.Data())
+	if err != nil {
+		logger.Error("error parsing job tx data", "txHash", tx.Hash(), "err", err)
+		return
+	}
+
+	jid, _, _, _, err := eth.GetInfoFromJobEvent(l, n.Eth)
+	if err != nil {
+		logger.Error("error getting info from job event", "txHash", tx.Hash(), "err", err)
+		return
+	}
+
+	//Create Transcode Config
+	//TODO: profile should contain multiple video profiles.  Waiting for a protocol change.
+	profile, ok := types.VideoProfileLookup[tData]
+	if !ok {
+		logger.Error("cannot find video profile for job", "jobID", jid, "tData", tData)
+		return
+	}
+
+	tProfiles := []types.VideoProfile{profile}
+	config := net.TranscodeConfig{StrmID: strmId, Profiles: tProfiles, JobID: jid, PerformOnchainClaim: true}
+	logger.Info("transcoder got job", "txHash", tx.Hash(), "jobID", jid, "strmID", strmId, "tData", tData)
+
+	//Do The Transcoding
+	cm := NewClaimManager(strmId, jid, tProfiles, n.Eth)
+	strmIDs, err := n.Transcode(config, cm)
+	if err != nil {
+		logger.Error("transcode error", "jobID", jid, "strmID", strmId, "err", err)
+		return
+	}
+
+	//Notify Broadcaster
+	sid := StreamID(strmId)
+	err = n.NotifyBroadcaster(sid.GetNodeID(), sid, map[StreamID]types.VideoProfile{strmIDs[0]: types.VideoProfileLookup[tData]})
+	if err != nil {
+		logger.Error("notify broadcaster error", "jobID", jid, "strmID", strmId, "err", err)
+	}
+}
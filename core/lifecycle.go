@@ -0,0 +1,32 @@
+package core
+
+import (
+	"context"
+
+	"github.com/livepeer/go-livepeer/net"
+)
+
+// Run brings the node's network participation up: it performs the
+// bootnode-vs-peer setup that used to happen inline in cmd/livepeer's main(),
+// then starts the VideoNetwork's own background loop.  Like VideoNetwork.Run,
+// it returns once things are up rather than blocking for the node's
+// lifetime - callers that want to block should wait on ctx themselves.
+// bootnodes is the candidate set to dial when this node isn't the bootnode
+// itself; VideoNetwork.Bootstrap is responsible for dialing them in parallel
+// and falling back across candidates.
+func (n *LivepeerNode) Run(ctx context.Context, bootnode bool, bootnodes []net.PeerRecord) error {
+	if bootnode {
+		if err := n.VideoNetwork.SetupProtocol(); err != nil {
+			return err
+		}
+	} else if err := n.VideoNetwork.Bootstrap(bootnodes); err != nil {
+		return err
+	}
+
+	return n.VideoNetwork.Run(ctx)
+}
+
+// Stop tears down whatever Run started.
+func (n *LivepeerNode) Stop() error {
+	return n.VideoNetwork.Stop()
+}
@@ -0,0 +1,41 @@
+// Package core holds the LivepeerNode, the central object every subsystem
+// (network, eth client, media server, transcoder) hangs off of.
+package core
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/livepeer/go-livepeer/common/log"
+	"github.com/livepeer/go-livepeer/eth"
+	"github.com/livepeer/go-livepeer/net"
+)
+
+var logger = log.New("module", "core")
+
+// Module wires the LivepeerNode into the Fx graph.  It is built with a nil eth
+// client; AttachEthClient fills that in when the eth module is included (see
+// eth.Module and cmd/livepeer's use of it).
+var Module = fx.Options(
+	fx.Provide(NewNode),
+)
+
+// NewNode constructs a LivepeerNode around the supplied VideoNetwork.  It does
+// no dialing or protocol setup - that happens in the lifecycle hooks cmd/livepeer
+// registers once the network has had a chance to bootstrap.
+func NewNode(nw net.VideoNetwork) (*LivepeerNode, error) {
+	n, err := NewLivepeerNode(nil, nw)
+	if err != nil {
+		logger.Error("error creating livepeer node", "err", err)
+		return nil, err
+	}
+	return n, nil
+}
+
+// AttachEthClient plugs an eth client into an already-constructed node.  It is
+// registered as an fx.Invoke by cmd/livepeer only when eth.gethipc is set,
+// mirroring how the old main() only set n.Eth inside the `if *gethipc != ""`
+// branch.
+func AttachEthClient(n *LivepeerNode, client eth.LivepeerEthClient, password string) {
+	n.Eth = client
+	n.EthPassword = password
+}
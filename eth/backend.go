@@ -0,0 +1,18 @@
+package eth
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Backend is the slice of *ethclient.Client that the rest of the module
+// reaches through LivepeerEthClient.Backend() - today just the lookup
+// job_subscriber.go uses to pull a job's tx data off a job event log.
+// Keeping it as an interface rather than the concrete *ethclient.Client lets
+// eth/simulated's SimulatedBackend-backed client satisfy it too, so the
+// transcode path can be exercised without a real geth node.
+type Backend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *ethtypes.Transaction, isPending bool, err error)
+}
@@ -0,0 +1,93 @@
+// Package eth wraps the Ethereum client used to talk to the Livepeer protocol
+// contracts.
+package eth
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/fx"
+
+	"github.com/livepeer/go-livepeer/common/log"
+)
+
+var logger = log.New("module", "eth")
+
+// Module wires a LivepeerEthClient into the Fx graph.  cmd/livepeer only
+// includes this module when eth.gethipc is set (by -gethipc, the config file
+// or LP_ETH_GETHIPC); a node running off-chain never
+// builds an eth client at all.
+var Module = fx.Options(
+	fx.Provide(NewClientFromConfig),
+)
+
+// ClientConfig carries the primitives NewClientFromConfig needs to dial geth
+// and load the account used to sign transactions. cmd/livepeer provides one
+// whole struct via fx.Provide(func() eth.ClientConfig {...}), so this stays
+// a plain value type - embedding fx.In here would make dig resolve each
+// field independently instead, and the fields (four bare strings among
+// them) have no name tags to disambiguate that.
+type ClientConfig struct {
+	GethIPC       string
+	ProtocolAddr  string
+	Datadir       string
+	Password      string
+	NewEthAccount bool
+	RPCTimeout    time.Duration
+	EventTimeout  time.Duration
+}
+
+// NewClientFromConfig dials geth over IPC, resolves (or creates) the signing
+// account, and returns a LivepeerEthClient bound to the protocol contract.
+// The account is also returned so other providers (e.g. the transcoder's
+// lifecycle hook) can use it without re-reading the keystore.
+func NewClientFromConfig(cfg ClientConfig) (LivepeerEthClient, accounts.Account, error) {
+	var acct accounts.Account
+	var err error
+
+	if cfg.NewEthAccount {
+		keyStore := keystore.NewKeyStore(filepath.Join(cfg.Datadir, "keystore"), keystore.StandardScryptN, keystore.StandardScryptP)
+		acct, err = keyStore.NewAccount(cfg.Password)
+		if err != nil {
+			logger.Error("error creating new eth account", "err", err)
+			return nil, acct, err
+		}
+	} else {
+		acct, err = getEthAccount(cfg.Datadir)
+		if err != nil {
+			logger.Error("error getting eth account", "err", err)
+			return nil, acct, err
+		}
+	}
+
+	logger.Info("connecting to geth", "gethipc", cfg.GethIPC)
+	backend, err := ethclient.Dial(cfg.GethIPC)
+	if err != nil {
+		logger.Error("failed to connect to ethereum client", "err", err)
+		return nil, acct, err
+	}
+
+	client, err := NewClient(acct, cfg.Password, cfg.Datadir, backend, common.HexToAddress(cfg.ProtocolAddr), cfg.RPCTimeout, cfg.EventTimeout)
+	if err != nil {
+		logger.Error("error creating eth client", "err", err)
+		return nil, acct, err
+	}
+
+	return client, acct, nil
+}
+
+func getEthAccount(datadir string) (accounts.Account, error) {
+	keyStore := keystore.NewKeyStore(filepath.Join(datadir, "keystore"), keystore.StandardScryptN, keystore.StandardScryptP)
+	accts := keyStore.Accounts()
+	if len(accts) == 0 {
+		logger.Error("cannot find geth account, make sure the data directory contains keys, or use -newEthAccount to create a new account")
+		return accounts.Account{}, fmt.Errorf("ErrGeth")
+	}
+
+	return accts[0], nil
+}
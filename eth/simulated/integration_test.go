@@ -0,0 +1,139 @@
+package simulated_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/livepeer/go-livepeer/eth/simulated"
+	"github.com/livepeer/go-livepeer/net/memnet"
+)
+
+// TestJobPropagatesToTranscoder spins up a simulated chain and an in-memory
+// video network, submits a job, and confirms it reaches the transcoder's
+// job-event subscription and that the resulting segment reaches a
+// broadcaster's subscriber - the same round trip job_subscriber.go drives in
+// production, minus geth, a deployed protocol contract, or a real libp2p
+// swarm.
+func TestJobPropagatesToTranscoder(t *testing.T) {
+	transcoderAcct := accounts.Account{Address: common.HexToAddress("0x1")}
+	ethClient := simulated.NewClient(transcoderAcct)
+	ethClient.SetActiveTranscoder(transcoderAcct.Address, big.NewInt(1000))
+
+	hub := memnet.NewHub()
+	broadcasterNet := memnet.New(hub, "broadcaster")
+	transcoderNet := memnet.New(hub, "transcoder")
+
+	const strmID = "teststream"
+	b, err := transcoderNet.GetBroadcaster(strmID)
+	if err != nil {
+		t.Fatalf("GetBroadcaster: %v", err)
+	}
+	sub, err := broadcasterNet.GetSubscriber(strmID)
+	if err != nil {
+		t.Fatalf("GetSubscriber: %v", err)
+	}
+
+	received := make(chan []byte, 1)
+	err = sub.Subscribe(context.Background(), func(seqNo uint64, data []byte, eof bool) {
+		if !eof {
+			received <- data
+		}
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	logsCh := make(chan ethtypes.Log)
+	if _, err := ethClient.SubscribeToJobEvent(context.Background(), logsCh); err != nil {
+		t.Fatalf("SubscribeToJobEvent: %v", err)
+	}
+
+	jobTx := common.HexToHash("0xabc")
+	// logsCh is unbuffered, so SubmitJob must run concurrently with the
+	// select below - it blocks on the send until something reads from
+	// logsCh.
+	go ethClient.SubmitJob(jobTx)
+
+	select {
+	case l := <-logsCh:
+		if l.TxHash != jobTx {
+			t.Fatalf("got job event for %v, want %v", l.TxHash, jobTx)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for job event")
+	}
+
+	if active, err := ethClient.IsActiveTranscoder(); err != nil || !active {
+		t.Fatalf("IsActiveTranscoder() = %v, %v; want true, nil", active, err)
+	}
+
+	// In production the transcoder would now call n.Transcode and push the
+	// resulting segment onto its own broadcaster; here we just confirm the
+	// memnet plumbing those segments travel over works end to end.
+	if err := b.Broadcast(0, []byte("transcoded segment")); err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+
+	select {
+	case data := <-received:
+		if string(data) != "transcoded segment" {
+			t.Fatalf("got %q, want %q", data, "transcoded segment")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for segment")
+	}
+}
+
+// TestSubmitJobDoesNotStallOnSlowSubscriber covers the N-transcoder case
+// TestJobPropagatesToTranscoder doesn't: with more than one job-event
+// subscriber on the same Client, a subscriber that isn't reading must not
+// block delivery to the others, or stall other Client methods called
+// concurrently with SubmitJob.
+func TestSubmitJobDoesNotStallOnSlowSubscriber(t *testing.T) {
+	acct := accounts.Account{Address: common.HexToAddress("0x1")}
+	ethClient := simulated.NewClient(acct)
+	ethClient.SetActiveTranscoder(acct.Address, big.NewInt(1000))
+
+	readyCh := make(chan ethtypes.Log, 1)
+	if _, err := ethClient.SubscribeToJobEvent(context.Background(), readyCh); err != nil {
+		t.Fatalf("SubscribeToJobEvent: %v", err)
+	}
+
+	// slowCh is never read from - the slow/unready subscriber SubmitJob
+	// must not let stall everyone else.
+	slowCh := make(chan ethtypes.Log)
+	if _, err := ethClient.SubscribeToJobEvent(context.Background(), slowCh); err != nil {
+		t.Fatalf("SubscribeToJobEvent: %v", err)
+	}
+
+	jobTx := common.HexToHash("0xdef")
+	ethClient.SubmitJob(jobTx)
+
+	select {
+	case l := <-readyCh:
+		if l.TxHash != jobTx {
+			t.Fatalf("got job event for %v, want %v", l.TxHash, jobTx)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for job event on the ready subscriber - a slow subscriber must not block others")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ethClient.IsActiveTranscoder()
+		ethClient.TranscoderStake()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Client methods stalled while a job-event subscriber was slow to read")
+	}
+}
@@ -0,0 +1,130 @@
+// Package simulated provides an in-process eth.LivepeerEthClient backed by
+// go-ethereum's SimulatedBackend instead of a real geth node, so the
+// transcoder's job-event path can run inside a plain Go test.
+//
+// This snapshot does not carry the protocol contract's Solidity sources or
+// ABI bindings, so rather than deploying the real contract, Client models
+// the slice of on-chain state job_subscriber.go reads (an account's active/
+// stake status, job event logs) directly and surfaces it through the same
+// methods production code calls. Swapping in real bind-generated contract
+// calls once the bindings are available is a drop-in change - nothing
+// outside this package needs to know the difference.
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/livepeer/go-livepeer/eth"
+)
+
+var _ eth.LivepeerEthClient = (*Client)(nil)
+
+// Client is an eth.LivepeerEthClient wrapping an in-process SimulatedBackend.
+// MineBlock advances the chain on demand; SetActiveTranscoder and SubmitJob
+// let a test drive the on-chain state the transcoder path reacts to.
+type Client struct {
+	Account accounts.Account
+
+	backend *backends.SimulatedBackend
+
+	mu                sync.Mutex
+	activeTranscoders map[common.Address]*big.Int
+	jobSubs           []chan ethtypes.Log
+}
+
+// NewClient funds acct with 1 ETH on a fresh SimulatedBackend and returns a
+// Client wrapping it. The chain does not advance on its own; call MineBlock
+// after submitting a transaction.
+func NewClient(acct accounts.Account) *Client {
+	alloc := ethcore.GenesisAlloc{
+		acct.Address: {Balance: new(big.Int).Mul(big.NewInt(1e18), big.NewInt(1))},
+	}
+	return &Client{
+		Account:           acct,
+		backend:           backends.NewSimulatedBackend(alloc, 8000000),
+		activeTranscoders: make(map[common.Address]*big.Int),
+	}
+}
+
+// MineBlock commits the pending block, mirroring how a test advances the
+// simulated chain after submitting a transaction.
+func (c *Client) MineBlock() {
+	c.backend.Commit()
+}
+
+// SetActiveTranscoder marks addr active with the given stake, as if it had
+// staked on the real protocol contract.
+func (c *Client) SetActiveTranscoder(addr common.Address, stake *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.activeTranscoders[addr] = stake
+}
+
+// IsActiveTranscoder reports whether this client's account is registered as
+// an active transcoder.
+func (c *Client) IsActiveTranscoder() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.activeTranscoders[c.Account.Address]
+	return ok, nil
+}
+
+// TranscoderStake returns this client's account's staked amount, or zero if
+// it is not an active transcoder.
+func (c *Client) TranscoderStake() (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stake, ok := c.activeTranscoders[c.Account.Address]
+	if !ok {
+		return big.NewInt(0), nil
+	}
+	return stake, nil
+}
+
+// SubscribeToJobEvent registers logsCh to receive every log SubmitJob
+// produces from here on, mirroring the real contract's job-assigned event.
+func (c *Client) SubscribeToJobEvent(ctx context.Context, logsCh chan ethtypes.Log) (ethereum.Subscription, error) {
+	c.mu.Lock()
+	c.jobSubs = append(c.jobSubs, logsCh)
+	c.mu.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
+
+// SubmitJob fabricates a job-assigned log for txHash and delivers it to
+// every current subscriber, as if a job had just been posted to the
+// protocol contract in that transaction. Sends happen after c.mu is
+// released, in their own goroutines: logsCh is unbuffered in production
+// (core/job_subscriber.go), so blocking on one subscriber's send while
+// holding the lock would stall every other method on c for every other
+// subscriber too.
+func (c *Client) SubmitJob(txHash common.Hash) {
+	c.mu.Lock()
+	subs := make([]chan ethtypes.Log, len(c.jobSubs))
+	copy(subs, c.jobSubs)
+	c.mu.Unlock()
+
+	l := ethtypes.Log{TxHash: txHash}
+	for _, ch := range subs {
+		go func(ch chan ethtypes.Log) { ch <- l }(ch)
+	}
+}
+
+// Backend exposes the SimulatedBackend through eth.Backend, the slice of
+// *ethclient.Client that job_subscriber.go relies on.
+func (c *Client) Backend() eth.Backend {
+	return c.backend
+}
@@ -0,0 +1,60 @@
+// Package mediaserver hosts the RTMP/HTTP media server LPMS sits behind.
+package mediaserver
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/livepeer/go-livepeer/common/log"
+	"github.com/livepeer/go-livepeer/core"
+)
+
+var logger = log.New("module", "mediaserver")
+
+// Module wires the LivepeerMediaServer into the Fx graph and registers the
+// lifecycle hooks that start/stop it, replacing the old main()'s
+// goroutine + select{} dance.
+var Module = fx.Options(
+	fx.Provide(NewServer),
+	fx.Invoke(registerLifecycle),
+)
+
+// ServerConfig carries the primitives NewServer needs to bind its listeners.
+// Both fields are bare strings with no name tags, so if this embedded fx.In,
+// dig would have no way to tell RTMPPort and HTTPPort apart when resolving
+// them as independent parameters - keeping ServerConfig a plain value type
+// provided whole avoids that ambiguity.
+type ServerConfig struct {
+	RTMPPort string
+	HTTPPort string
+}
+
+// NewServer constructs a LivepeerMediaServer bound to n.  It does not start
+// listening; that is left to the OnStart hook registerLifecycle installs.
+func NewServer(cfg ServerConfig, n *core.LivepeerNode) *LivepeerMediaServer {
+	return NewLivepeerMediaServer(cfg.RTMPPort, cfg.HTTPPort, "", n)
+}
+
+func registerLifecycle(lc fx.Lifecycle, s *LivepeerMediaServer) {
+	var cancel context.CancelFunc
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			var msCtx context.Context
+			msCtx, cancel = context.WithCancel(context.Background())
+			go func() {
+				if err := s.StartMediaServer(msCtx); err != nil {
+					logger.Error("error from media server", "err", err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if cancel != nil {
+				cancel()
+			}
+			return nil
+		},
+	})
+}
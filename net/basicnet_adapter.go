@@ -0,0 +1,123 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bnet "github.com/livepeer/go-livepeer-basicnet"
+)
+
+// bootstrapDialTimeout bounds how long Bootstrap waits on a single
+// candidate's Connect before treating it as unreachable and falling back to
+// the next one. bnet.Connect takes no context of its own, so the timeout is
+// enforced here rather than assumed from bnet.
+const bootstrapDialTimeout = 10 * time.Second
+
+// basicNetworkAdapter adapts a bnet.BasicVideoNetwork - which only
+// implements the original GetNodeID/GetBroadcaster/GetSubscriber/Connect/
+// SetupProtocol/SendTranscodeResult surface - to the full VideoNetwork
+// interface this package now exposes. bnet drives its own libp2p swarm
+// internally once Connect/SetupProtocol are called, so Run/Stop only
+// bookend that lifecycle; Bootstrap and KnownPeers are implemented here
+// since bnet has no notion of either.
+type basicNetworkAdapter struct {
+	*bnet.BasicVideoNetwork
+
+	mu     sync.Mutex
+	peers  []PeerRecord
+	cancel context.CancelFunc
+}
+
+// newBasicNetworkAdapter wraps nw so it satisfies VideoNetwork.
+func newBasicNetworkAdapter(nw *bnet.BasicVideoNetwork) *basicNetworkAdapter {
+	return &basicNetworkAdapter{BasicVideoNetwork: nw}
+}
+
+// Run has nothing of its own to start - bnet's swarm is already driven by
+// Connect/SetupProtocol - so it just gives Stop something to cancel.
+func (a *basicNetworkAdapter) Run(ctx context.Context) error {
+	_, cancel := context.WithCancel(context.Background())
+
+	a.mu.Lock()
+	a.cancel = cancel
+	a.mu.Unlock()
+
+	return nil
+}
+
+// Stop cancels whatever Run started. Safe to call even if Run was never
+// called or returned an error.
+func (a *basicNetworkAdapter) Stop() error {
+	a.mu.Lock()
+	cancel := a.cancel
+	a.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return nil
+}
+
+// Bootstrap dials every candidate in parallel via Connect, bounding each
+// dial to bootstrapDialTimeout so one unreachable candidate can't stall
+// Fx's OnStart, and keeps whatever succeeds.
+func (a *basicNetworkAdapter) Bootstrap(peers []PeerRecord) error {
+	type dialResult struct {
+		peer PeerRecord
+		err  error
+	}
+
+	results := make(chan dialResult, len(peers))
+	for _, p := range peers {
+		go func(p PeerRecord) {
+			if len(p.Multiaddrs) == 0 {
+				results <- dialResult{p, fmt.Errorf("peer %s has no multiaddrs", p.PeerID)}
+				return
+			}
+
+			dialErr := make(chan error, 1)
+			go func() { dialErr <- a.Connect(p.PeerID, p.Multiaddrs[0]) }()
+
+			select {
+			case err := <-dialErr:
+				results <- dialResult{p, err}
+			case <-time.After(bootstrapDialTimeout):
+				results <- dialResult{p, fmt.Errorf("dialing peer %s timed out after %s", p.PeerID, bootstrapDialTimeout)}
+			}
+		}(p)
+	}
+
+	var lastErr error
+	connected := 0
+	for range peers {
+		r := <-results
+		if r.err != nil {
+			lastErr = r.err
+			continue
+		}
+		a.mu.Lock()
+		a.peers = append(a.peers, r.peer)
+		a.mu.Unlock()
+		connected++
+	}
+
+	if connected == 0 {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no bootstrap peers supplied")
+		}
+		return lastErr
+	}
+	return nil
+}
+
+// KnownPeers returns the peers Bootstrap has successfully dialed so far.
+func (a *basicNetworkAdapter) KnownPeers() []PeerRecord {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	peers := make([]PeerRecord, len(a.peers))
+	copy(peers, a.peers)
+	return peers
+}
@@ -0,0 +1,86 @@
+package net
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadBootnodes assembles the initial bootstrap set from a list of
+// "peerID@multiaddr" entries (e.g. the -bootnodes flag, comma-split by the
+// caller) and datadir/bootnodes.json, a seed file an operator can ship
+// alongside the binary. Either source may be empty.
+func LoadBootnodes(datadir string, entries []string) ([]PeerRecord, error) {
+	var peers []PeerRecord
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "@", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		peers = append(peers, PeerRecord{PeerID: parts[0], Multiaddrs: []string{parts[1]}})
+	}
+
+	seeded, err := readPeerFile(filepath.Join(datadir, "bootnodes.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(peers, seeded...), nil
+}
+
+// LoadKnownPeers reads the peerstore snapshot persisted to datadir/peers.json
+// by a previous run, so a restart can warm-start without the seed node. A
+// missing file is not an error - there is simply nothing to warm-start from
+// yet.
+func LoadKnownPeers(datadir string) ([]PeerRecord, error) {
+	return readPeerFile(filepath.Join(datadir, "peers.json"))
+}
+
+// PersistPeers writes peers to datadir/peers.json, overwriting whatever was
+// there before - the full peerstore snapshot a periodic persistence loop
+// takes.
+func PersistPeers(datadir string, peers []PeerRecord) error {
+	b, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(datadir, "peers.json"), b, 0644)
+}
+
+// readPeerFile loads the PeerRecords persisted at path, dropping any that
+// fail Verify - a signature that doesn't verify against its own embedded
+// PubKey, or a PeerID not derived from that PubKey. A tampered or corrupted
+// bootnodes.json/peers.json shouldn't be trusted just because it parses as
+// JSON.
+func readPeerFile(path string) ([]PeerRecord, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []PeerRecord
+	if err := json.Unmarshal(b, &peers); err != nil {
+		return nil, err
+	}
+
+	verified := peers[:0]
+	for _, p := range peers {
+		ok, err := p.Verify()
+		if err != nil || !ok {
+			logger.Error("dropping peer record with invalid signature", "path", path, "peerId", p.PeerID, "err", err)
+			continue
+		}
+		verified = append(verified, p)
+	}
+	return verified, nil
+}
@@ -0,0 +1,148 @@
+package net
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	crypto "gx/ipfs/QmaPbCnUMBohSGo3KnxEa2bHqyJVVeEEcwtqJAYxerieBo/go-libp2p-crypto"
+)
+
+func writePeerFile(t *testing.T, datadir, name string, peers []PeerRecord) {
+	t.Helper()
+
+	b, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal peers: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(datadir, name), b, 0644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+// signedTestPeer returns a validly-signed PeerRecord for a freshly generated
+// keypair. PeerID is derived from that keypair, not chosen by the caller -
+// tests that need to tell two peers apart compare the PeerID the record
+// actually carries.
+func signedTestPeer(t *testing.T) PeerRecord {
+	t.Helper()
+
+	priv, pub, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	r, err := NewPeerRecord(priv, pub, hexPeerID(t, pub), []string{"/ip4/1.2.3.4/tcp/15000"}, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord: %v", err)
+	}
+	return r
+}
+
+func TestLoadKnownPeersRoundTrip(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "peers")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(datadir)
+
+	good := signedTestPeer(t)
+	if err := PersistPeers(datadir, []PeerRecord{good}); err != nil {
+		t.Fatalf("PersistPeers: %v", err)
+	}
+
+	peers, err := LoadKnownPeers(datadir)
+	if err != nil {
+		t.Fatalf("LoadKnownPeers: %v", err)
+	}
+	if len(peers) != 1 || peers[0].PeerID != good.PeerID {
+		t.Fatalf("LoadKnownPeers() = %+v, want [%s]", peers, good.PeerID)
+	}
+}
+
+func TestLoadKnownPeersDropsTamperedEntries(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "peers")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(datadir)
+
+	good := signedTestPeer(t)
+	bad := signedTestPeer(t)
+	bad.Multiaddrs = []string{"/ip4/6.6.6.6/tcp/15000"}
+
+	writePeerFile(t, datadir, "peers.json", []PeerRecord{good, bad})
+
+	peers, err := LoadKnownPeers(datadir)
+	if err != nil {
+		t.Fatalf("LoadKnownPeers: %v", err)
+	}
+	if len(peers) != 1 || peers[0].PeerID != good.PeerID {
+		t.Fatalf("LoadKnownPeers() = %+v, want only %s - tampered entry should be dropped", peers, good.PeerID)
+	}
+}
+
+func TestLoadKnownPeersDropsForgedPeerID(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "peers")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(datadir)
+
+	good := signedTestPeer(t)
+	forged := signedTestPeer(t)
+	forged.PeerID = good.PeerID // attacker claims a trusted peer's identity
+
+	writePeerFile(t, datadir, "peers.json", []PeerRecord{good, forged})
+
+	peers, err := LoadKnownPeers(datadir)
+	if err != nil {
+		t.Fatalf("LoadKnownPeers: %v", err)
+	}
+	if len(peers) != 1 || peers[0].PeerID != good.PeerID {
+		t.Fatalf("LoadKnownPeers() = %+v, want only the genuine %s - record with a PeerID not derived from its own PubKey should be dropped", peers, good.PeerID)
+	}
+}
+
+func TestLoadKnownPeersMissingFile(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "peers")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(datadir)
+
+	peers, err := LoadKnownPeers(datadir)
+	if err != nil {
+		t.Fatalf("LoadKnownPeers: %v", err)
+	}
+	if peers != nil {
+		t.Fatalf("LoadKnownPeers() = %+v, want nil for a missing peers.json", peers)
+	}
+}
+
+func TestLoadBootnodesMergesFlagEntriesAndSeedFile(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "bootnodes")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(datadir)
+
+	seeded := signedTestPeer(t)
+	writePeerFile(t, datadir, "bootnodes.json", []PeerRecord{seeded})
+
+	peers, err := LoadBootnodes(datadir, []string{" peerA@/ip4/9.9.9.9/tcp/15000 ", "", "malformed"})
+	if err != nil {
+		t.Fatalf("LoadBootnodes: %v", err)
+	}
+
+	if len(peers) != 2 {
+		t.Fatalf("LoadBootnodes() = %+v, want 2 peers (1 flag entry + 1 seeded)", peers)
+	}
+	if peers[0].PeerID != "peerA" {
+		t.Fatalf("peers[0].PeerID = %q, want %q", peers[0].PeerID, "peerA")
+	}
+	if peers[1].PeerID != seeded.PeerID {
+		t.Fatalf("peers[1].PeerID = %q, want %q", peers[1].PeerID, seeded.PeerID)
+	}
+}
@@ -14,6 +14,25 @@ type VideoNetwork interface {
 	Connect(nodeID, nodeAddr string) error
 	SetupProtocol() error
 	SendTranscodeResult(nodeID string, strmID string, transcodeResult map[string]string) error
+
+	//Run starts the network's background message-processing loop and returns
+	//once it is up - it does not block for the loop's lifetime.  Construction
+	//(see NewBasicVideoNetwork) does no I/O; Run is where it begins.
+	Run(ctx context.Context) error
+	//Stop tears down whatever Run started.  Safe to call even if Run was
+	//never called or returned an error.
+	Stop() error
+
+	//Bootstrap dials peers in parallel, falling back to the next candidate if
+	//a dial doesn't complete within the implementation's own timeout, and
+	//adds whatever succeeds to the peerstore KnownPeers reports from.  It is
+	//the multi-candidate replacement for dialing a single hardcoded
+	//bootnode.
+	Bootstrap(peers []PeerRecord) error
+	//KnownPeers returns the peer records currently in the peerstore - the
+	//ones Bootstrap has added - so a caller can persist them for a future
+	//restart to warm-start from.
+	KnownPeers() []PeerRecord
 }
 
 //Broadcaster takes a streamID and a reader, and broadcasts the data to whatever underlining network.
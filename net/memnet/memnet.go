@@ -0,0 +1,172 @@
+// Package memnet is an in-process net.VideoNetwork for tests. Instead of a
+// libp2p swarm, broadcasters and subscribers sharing a Hub talk over Go
+// channels, so a test can spin up several nodes in one process with no
+// ports, no docker, and no real network stack.
+package memnet
+
+import (
+	"context"
+	"sync"
+
+	"github.com/livepeer/go-livepeer/net"
+)
+
+// Hub is the shared medium every Network dials into - the in-process
+// equivalent of the libp2p swarm a real VideoNetwork runs over.
+type Hub struct {
+	mu      sync.Mutex
+	streams map[string]*stream
+}
+
+// NewHub returns an empty Hub. Networks sharing a Hub can broadcast to and
+// subscribe from one another's streams.
+func NewHub() *Hub {
+	return &Hub{streams: make(map[string]*stream)}
+}
+
+func (h *Hub) stream(strmID string) *stream {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.streams[strmID]
+	if !ok {
+		s = &stream{}
+		h.streams[strmID] = s
+	}
+	return s
+}
+
+type segment struct {
+	seqNo uint64
+	data  []byte
+	eof   bool
+}
+
+type stream struct {
+	mu   sync.Mutex
+	subs []chan segment
+}
+
+func (s *stream) addSub() chan segment {
+	ch := make(chan segment, 16)
+	s.mu.Lock()
+	s.subs = append(s.subs, ch)
+	s.mu.Unlock()
+	return ch
+}
+
+// publish snapshots the current subscribers under s.mu, then sends outside
+// the lock: each channel is buffered but still blocks once full, and a slow
+// or unread subscriber holding s.mu would stall addSub and every other
+// subscriber's delivery too - the same hazard simulated.Client.SubmitJob was
+// fixed to avoid.
+func (s *stream) publish(seg segment) {
+	s.mu.Lock()
+	subs := make([]chan segment, len(s.subs))
+	copy(subs, s.subs)
+	s.mu.Unlock()
+
+	for _, ch := range subs {
+		go func(ch chan segment) { ch <- seg }(ch)
+	}
+}
+
+// Network is a net.VideoNetwork whose broadcasters and subscribers are
+// routed through a shared Hub rather than a libp2p swarm. Connect and
+// SetupProtocol are no-ops - there is no real transport to dial or
+// advertise on.
+type Network struct {
+	nodeID string
+	hub    *Hub
+
+	mu    sync.Mutex
+	peers []net.PeerRecord
+}
+
+// New returns a Network identified as nodeID, routed through hub.
+func New(hub *Hub, nodeID string) *Network {
+	return &Network{nodeID: nodeID, hub: hub}
+}
+
+func (n *Network) GetNodeID() string { return n.nodeID }
+
+func (n *Network) GetBroadcaster(strmID string) (net.Broadcaster, error) {
+	return &broadcaster{stream: n.hub.stream(strmID)}, nil
+}
+
+func (n *Network) GetSubscriber(strmID string) (net.Subscriber, error) {
+	return &subscriber{stream: n.hub.stream(strmID)}, nil
+}
+
+func (n *Network) Connect(nodeID, nodeAddr string) error { return nil }
+
+func (n *Network) SetupProtocol() error { return nil }
+
+func (n *Network) SendTranscodeResult(nodeID string, strmID string, transcodeResult map[string]string) error {
+	return nil
+}
+
+// Run is a no-op; there is no background transport loop to start.
+func (n *Network) Run(ctx context.Context) error { return nil }
+
+// Stop is a no-op; Run started nothing that needs tearing down.
+func (n *Network) Stop() error { return nil }
+
+// Bootstrap records peers as known. There is no real transport to dial over
+// a Hub, so every peer is considered reachable immediately.
+func (n *Network) Bootstrap(peers []net.PeerRecord) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.peers = append(n.peers, peers...)
+	return nil
+}
+
+// KnownPeers returns the peers passed to Bootstrap so far.
+func (n *Network) KnownPeers() []net.PeerRecord {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	peers := make([]net.PeerRecord, len(n.peers))
+	copy(peers, n.peers)
+	return peers
+}
+
+type broadcaster struct {
+	stream *stream
+}
+
+func (b *broadcaster) Broadcast(seqNo uint64, data []byte) error {
+	b.stream.publish(segment{seqNo: seqNo, data: data})
+	return nil
+}
+
+func (b *broadcaster) Finish() error {
+	b.stream.publish(segment{eof: true})
+	return nil
+}
+
+type subscriber struct {
+	stream *stream
+}
+
+// Subscribe registers gotData against the stream and starts delivering
+// segments in the background. It returns once the subscription is in
+// place; it does not block for the stream's lifetime.
+func (s *subscriber) Subscribe(ctx context.Context, gotData func(seqNo uint64, data []byte, eof bool)) error {
+	ch := s.stream.addSub()
+
+	go func() {
+		for {
+			select {
+			case seg := <-ch:
+				gotData(seg.seqNo, seg.data, seg.eof)
+				if seg.eof {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *subscriber) Unsubscribe() error { return nil }
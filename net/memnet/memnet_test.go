@@ -0,0 +1,70 @@
+package memnet
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestPublishDoesNotStallOnSlowSubscriber covers the N-subscriber case: with
+// more than one subscriber on the same stream, a subscriber that isn't
+// reading must not block delivery to the others, or block a concurrent
+// addSub (a new Subscribe call) on the same stream.
+func TestPublishDoesNotStallOnSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+	nw := New(hub, "node")
+
+	const strmID = "teststream"
+	b, err := nw.GetBroadcaster(strmID)
+	if err != nil {
+		t.Fatalf("GetBroadcaster: %v", err)
+	}
+
+	// slow never reads, so its channel fills and stays full.
+	s := nw.hub.stream(strmID)
+	slow := s.addSub()
+
+	ready, err := nw.GetSubscriber(strmID)
+	if err != nil {
+		t.Fatalf("GetSubscriber: %v", err)
+	}
+	received := make(chan []byte, 1)
+	err = ready.Subscribe(context.Background(), func(seqNo uint64, data []byte, eof bool) {
+		if !eof {
+			received <- data
+		}
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Fill slow's buffer so any future send to it blocks.
+	for i := 0; i < cap(slow); i++ {
+		slow <- segment{seqNo: uint64(i)}
+	}
+
+	if err := b.Broadcast(0, []byte("segment")); err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+
+	select {
+	case data := <-received:
+		if string(data) != "segment" {
+			t.Fatalf("got %q, want %q", data, "segment")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for segment on the ready subscriber - a slow subscriber must not block others")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.addSub()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("addSub stalled while a subscriber was slow to read")
+	}
+}
@@ -0,0 +1,51 @@
+package net
+
+import (
+	crypto "gx/ipfs/QmaPbCnUMBohSGo3KnxEa2bHqyJVVeEEcwtqJAYxerieBo/go-libp2p-crypto"
+
+	bnet "github.com/livepeer/go-livepeer-basicnet"
+	"github.com/livepeer/go-livepeer/common/log"
+	"go.uber.org/fx"
+)
+
+var logger = log.New("module", "net")
+
+// Module wires a libp2p-backed VideoNetwork into the Fx graph.  It is responsible
+// for constructing the underlying bnet.Node; dialing peers and setting up the
+// protocol is left to the lifecycle hooks cmd/livepeer registers once the rest
+// of the graph (eth client, core node) has been built.
+var Module = fx.Options(
+	fx.Provide(NewBasicVideoNetwork),
+)
+
+// NetworkConfig carries the primitives NewBasicVideoNetwork needs to stand up the
+// libp2p node.  cmd/livepeer supplies it from the resolved LivepeerConfig and
+// the keys loaded from its datadir. PrivKey and PubKey are interface-typed,
+// which dig resolves by the concrete type stored in them rather than by
+// field name - an fx.In struct with two differently-shaped key fields would
+// work by accident today and break the moment that changes, so this stays a
+// plain value type provided whole instead.
+type NetworkConfig struct {
+	Port    int
+	PrivKey crypto.PrivKey
+	PubKey  crypto.PubKey
+}
+
+// NewBasicVideoNetwork constructs the bnet.Node and wraps it in a
+// basicNetworkAdapter around a BasicVideoNetwork, so the result satisfies
+// the full VideoNetwork interface. It does no dialing or protocol setup.
+func NewBasicVideoNetwork(cfg NetworkConfig) (VideoNetwork, error) {
+	node, err := bnet.NewNode(cfg.Port, cfg.PrivKey, cfg.PubKey)
+	if err != nil {
+		logger.Error("error creating a new node", "err", err)
+		return nil, err
+	}
+
+	nw, err := bnet.NewBasicVideoNetwork(node)
+	if err != nil {
+		logger.Error("cannot create network node", "err", err)
+		return nil, err
+	}
+
+	return newBasicNetworkAdapter(nw), nil
+}
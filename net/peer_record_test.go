@@ -0,0 +1,155 @@
+package net
+
+import (
+	"encoding/hex"
+	"testing"
+
+	crypto "gx/ipfs/QmaPbCnUMBohSGo3KnxEa2bHqyJVVeEEcwtqJAYxerieBo/go-libp2p-crypto"
+)
+
+// hexPeerID returns the hex-encoded peer ID expected for pub, the same way
+// a real bnet-derived node ID would be hex-encoded (see peerIDMultihash).
+func hexPeerID(t *testing.T, pub crypto.PubKey) string {
+	t.Helper()
+
+	mh, err := peerIDMultihash(pub)
+	if err != nil {
+		t.Fatalf("peerIDMultihash: %v", err)
+	}
+	return hex.EncodeToString(mh)
+}
+
+func TestPeerRecordVerifyRoundTrip(t *testing.T) {
+	priv, pub, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	r, err := NewPeerRecord(priv, pub, hexPeerID(t, pub), []string{"/ip4/1.2.3.4/tcp/15000"}, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord: %v", err)
+	}
+
+	ok, err := r.Verify()
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok {
+		t.Fatal("Verify() = false, want true for an untampered record")
+	}
+}
+
+func TestPeerRecordVerifyRejectsTamperedFields(t *testing.T) {
+	priv, pub, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	r, err := NewPeerRecord(priv, pub, hexPeerID(t, pub), []string{"/ip4/1.2.3.4/tcp/15000"}, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord: %v", err)
+	}
+
+	r.Multiaddrs = []string{"/ip4/6.6.6.6/tcp/15000"}
+
+	ok, err := r.Verify()
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if ok {
+		t.Fatal("Verify() = true for a record whose multiaddrs were tampered with after signing")
+	}
+}
+
+func TestPeerRecordVerifyRejectsForeignSignature(t *testing.T) {
+	priv1, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	_, pub2, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	// Sign with priv1 but swap in an unrelated peer's pubkey, as if an
+	// attacker tried to pass priv1's signature off as pub2's.
+	r, err := NewPeerRecord(priv1, pub2, hexPeerID(t, pub2), []string{"/ip4/1.2.3.4/tcp/15000"}, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord: %v", err)
+	}
+
+	ok, err := r.Verify()
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if ok {
+		t.Fatal("Verify() = true for a signature that doesn't match the embedded pubkey")
+	}
+}
+
+func TestPeerRecordVerifyRejectsForgedPeerID(t *testing.T) {
+	attackerPriv, attackerPub, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+
+	// An attacker signs a legitimate record with their own key, then
+	// overwrites PeerID with a trusted-looking value after the fact - an
+	// attack readPeerFile relies on Verify to reject. Verify must catch
+	// this even though the signature
+	// itself, recomputed over the doctored PeerID, still checks out against
+	// the attacker's own embedded PubKey.
+	r, err := NewPeerRecord(attackerPriv, attackerPub, hexPeerID(t, attackerPub), []string{"/ip4/6.6.6.6/tcp/15000"}, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord: %v", err)
+	}
+	r.PeerID = "12208a4eb428aa57a74ef0593612adb88077c75c71ad07c3c26e4e7a8d4860083b01"
+	b, err := r.signingBytes()
+	if err != nil {
+		t.Fatalf("signingBytes: %v", err)
+	}
+	sig, err := attackerPriv.Sign(b)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	r.Signature = sig
+
+	ok, err := r.Verify()
+	if err == nil {
+		t.Fatal("Verify() returned no error for a PeerID not derived from its PubKey")
+	}
+	if ok {
+		t.Fatal("Verify() = true for a forged PeerID not bound to its PubKey")
+	}
+}
+
+// TestPeerIDMultihashMatchesBootIDConvention pins peerIDMultihash's output
+// format to the one real bnet peer ID this codebase has on hand:
+// config.Default()'s hex-literal BootID. We don't have bnet's private key,
+// so we can't reproduce that exact ID, but its structure - a hex-encoded
+// 34-byte value starting with the 0x12 0x20 sha2-256 multihash prefix - is
+// exactly what peerIDMultihash produces, confirming PeerRecord's encoding
+// agrees with the rest of this codebase rather than inventing its own.
+func TestPeerIDMultihashMatchesBootIDConvention(t *testing.T) {
+	const bootID = "12208a4eb428aa57a74ef0593612adb88077c75c71ad07c3c26e4e7a8d4860083b01"
+
+	decoded, err := hex.DecodeString(bootID)
+	if err != nil {
+		t.Fatalf("decoding config.Default()'s BootID: %v", err)
+	}
+	if len(decoded) != 34 || decoded[0] != 0x12 || decoded[1] != 0x20 {
+		t.Fatalf("config.Default()'s BootID %q isn't a 0x12 0x20 sha2-256 multihash", bootID)
+	}
+
+	_, pub, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	mh, err := peerIDMultihash(pub)
+	if err != nil {
+		t.Fatalf("peerIDMultihash: %v", err)
+	}
+	if len(mh) != len(decoded) || mh[0] != decoded[0] || mh[1] != decoded[1] {
+		t.Fatalf("peerIDMultihash() = %x, want the same 0x12 0x20-prefixed, %d-byte shape as BootID", mh, len(decoded))
+	}
+}